@@ -2,12 +2,15 @@ package client
 
 import (
 	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"time"
 )
@@ -58,13 +61,170 @@ func (rs *FlatResourceSet) MarshalJSON() ([]byte, error) {
 }
 
 type VmBackup struct {
-	Vms      map[string]string `json:"vms"`
-	Remotes  map[string]string `json:"remotes"`
-	Type     string            `json:"type,omitempty"`
-	Id       string            `json:"id,omitempty"`
-	Settings map[string]string `json:"settings,omitempty"`
-	Name     string            `json:"name"`
-	Mode     string            `json:"mode"`
+	Vms       map[string]string `json:"vms"`
+	Remotes   map[string]string `json:"remotes"`
+	Type      string            `json:"type,omitempty"`
+	Id        string            `json:"id,omitempty"`
+	Settings  map[string]string `json:"settings,omitempty"`
+	Name      string            `json:"name"`
+	Mode      string            `json:"mode"`
+	Schedules []BackupSchedule  `json:"-"`
+}
+
+// Retention controls how many exports, copies and snapshots a given
+// BackupSchedule keeps around, as well as how often a full (rather than
+// delta) export is taken. It is sent to the API as part of the
+// per-schedule entry of the `settings` map rather than on the schedule
+// object itself.
+type Retention struct {
+	ExportRetention   int `json:"exportRetention,omitempty"`
+	CopyRetention     int `json:"copyRetention,omitempty"`
+	SnapshotRetention int `json:"snapshotRetention,omitempty"`
+	FullInterval      int `json:"fullInterval,omitempty"`
+}
+
+// BackupSchedule represents a single cron-driven run of a VmBackup. A
+// VmBackup can have many schedules, e.g. a nightly incremental export and
+// a weekly full export with a different retention policy.
+type BackupSchedule struct {
+	Id        string
+	Cron      string
+	Timezone  string
+	Enabled   bool
+	Name      string
+	Retention Retention
+}
+
+type rawSchedule struct {
+	Cron     string `json:"cron"`
+	Timezone string `json:"timezone,omitempty"`
+	Enabled  bool   `json:"enabled"`
+	Name     string `json:"name,omitempty"`
+}
+
+// UnmarshalJSON flattens the `schedules` and `settings` maps that the API
+// returns (both keyed by schedule id, with a `""` key in `settings` for
+// job-level settings such as reportWhen) into the Schedules slice and the
+// flat Settings map that resource_backup.go expects.
+func (v *VmBackup) UnmarshalJSON(data []byte) error {
+	type vmBackupAlias VmBackup
+	aux := &struct {
+		Schedules map[string]rawSchedule     `json:"schedules,omitempty"`
+		Settings  map[string]json.RawMessage `json:"settings,omitempty"`
+		*vmBackupAlias
+	}{
+		vmBackupAlias: (*vmBackupAlias)(v),
+	}
+
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+
+	v.Settings = map[string]string{}
+	v.Schedules = nil
+	for id, sched := range aux.Schedules {
+		schedule := BackupSchedule{
+			Id:       id,
+			Cron:     sched.Cron,
+			Timezone: sched.Timezone,
+			Enabled:  sched.Enabled,
+			Name:     sched.Name,
+		}
+		if raw, ok := aux.Settings[id]; ok {
+			if err := json.Unmarshal(raw, &schedule.Retention); err != nil {
+				return err
+			}
+		}
+		v.Schedules = append(v.Schedules, schedule)
+	}
+
+	// aux.Schedules is a map, so range order is randomized. Sort by id so
+	// repeated reads of the same job return schedules in a stable order
+	// and don't produce spurious plan diffs in the `schedule` TypeList.
+	sort.Slice(v.Schedules, func(i, j int) bool {
+		return v.Schedules[i].Id < v.Schedules[j].Id
+	})
+
+	if raw, ok := aux.Settings[""]; ok {
+		var jobSettings map[string]string
+		if err := json.Unmarshal(raw, &jobSettings); err != nil {
+			return err
+		}
+		v.Settings = jobSettings
+	}
+
+	return nil
+}
+
+// newScheduleId generates a placeholder id for a schedule that doesn't
+// have one yet (i.e. one just added in Terraform config). It must be
+// unique across the job's existing schedules so that a newly added
+// schedule can never collide with - and silently overwrite - one created
+// on a previous apply.
+func newScheduleId() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand failing is effectively unrecoverable; fall back to
+		// a value that is still extremely unlikely to collide.
+		return fmt.Sprintf("schedule-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// backupSchedulesParams translates the Schedules slice and flat Settings
+// map on a VmBackup into the `schedules`/`settings` shape that
+// backup.create and backup.set expect: schedules keyed by schedule id,
+// and settings keyed by schedule id plus a `""` key for job-level
+// settings like reportWhen, concurrency and timeout.
+func backupSchedulesParams(backupReq VmBackup) (map[string]interface{}, map[string]interface{}, error) {
+	schedules := make(map[string]interface{})
+	settings := make(map[string]interface{})
+
+	for _, schedule := range backupReq.Schedules {
+		id := schedule.Id
+		if id == "" {
+			id = newScheduleId()
+		}
+		schedules[id] = map[string]interface{}{
+			"cron":     schedule.Cron,
+			"timezone": schedule.Timezone,
+			"enabled":  schedule.Enabled,
+			"name":     schedule.Name,
+		}
+
+		retentionSettings, err := retentionToSettings(schedule.Retention)
+		if err != nil {
+			return nil, nil, err
+		}
+		settings[id] = retentionSettings
+	}
+
+	jobSettings := make(map[string]interface{})
+	for k, v := range backupReq.Settings {
+		jobSettings[k] = v
+	}
+	settings[""] = jobSettings
+
+	return schedules, settings, nil
+}
+
+// retentionToSettings marshals Retention through encoding/json instead of
+// building a literal map, so that its `omitempty` tags are honored: a
+// retention field the user left unset is omitted from the request
+// entirely rather than sent as an explicit 0, which Xen Orchestra
+// interprets as "keep none" rather than "use the job/server default".
+func retentionToSettings(retention Retention) (map[string]interface{}, error) {
+	raw, err := json.Marshal(retention)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings map[string]interface{}
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
 }
 
 func (v VmBackup) Compare(obj interface{}) bool {
@@ -77,24 +237,32 @@ func (v VmBackup) Compare(obj interface{}) bool {
 		return true
 	}
 
+	if v.Mode != "" && v.Mode == other.Mode {
+		return true
+	}
+
 	return false
 }
 
 func (c *Client) CreateBackup(backupReq VmBackup, createTime time.Duration) (*VmBackup, error) {
 
+	schedules, settings, err := backupSchedulesParams(backupReq)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]interface{}{
-		"enabled":  false,
-		"type":     backupReq.Type,
-		"name":     backupReq.Name,
-		"vms":      backupReq.Vms,
-		"mode":     backupReq.Mode,
-		"remotes":  backupReq.Remotes,
-		"settings": backupReq.Settings,
+		"enabled":   false,
+		"type":      backupReq.Type,
+		"name":      backupReq.Name,
+		"vms":       backupReq.Vms,
+		"mode":      backupReq.Mode,
+		"remotes":   backupReq.Remotes,
+		"schedules": schedules,
+		"settings":  settings,
 	}
 
 	log.Printf("[DEBUG] Backup params for backup.create %#v", params)
 	var backupId string
-	var err error
 	err = c.Call("backup.create", params, &backupId)
 
 	if err != nil {
@@ -124,19 +292,24 @@ func (c *Client) CreateBackup(backupReq VmBackup, createTime time.Duration) (*Vm
 }
 
 func (c *Client) UpdateBackup(backupReq VmBackup) (*VmBackup, error) {
+	schedules, settings, err := backupSchedulesParams(backupReq)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]interface{}{
-		"id":       backupReq.Id,
-		"enabled":  false,
-		"type":     backupReq.Type,
-		"name":     backupReq.Name,
-		"vms":      backupReq.Vms,
-		"mode":     backupReq.Mode,
-		"remotes":  backupReq.Remotes,
-		"settings": backupReq.Settings,
+		"id":        backupReq.Id,
+		"enabled":   false,
+		"type":      backupReq.Type,
+		"name":      backupReq.Name,
+		"vms":       backupReq.Vms,
+		"mode":      backupReq.Mode,
+		"remotes":   backupReq.Remotes,
+		"schedules": schedules,
+		"settings":  settings,
 	}
 
 	var success bool
-	err := c.Call("backup.set", params, &success)
+	err = c.Call("backup.set", params, &success)
 
 	if err != nil {
 		return nil, err
@@ -161,6 +334,144 @@ func (c *Client) DeleteBackup(id string) error {
 	return c.Call("backup.delete", params, &reply)
 }
 
+const (
+	BackupLogSuccess     string = "success"
+	BackupLogFailure     string = "failure"
+	BackupLogInterrupted string = "interrupted"
+)
+
+// backupLogPollInterval is how often RunBackup polls backup.getLogs while
+// waiting for a triggered job to finish.
+const backupLogPollInterval = 5 * time.Second
+
+// BackupTaskResult carries the per-task outcome of a backup run, such as
+// the number of bytes transferred for a given VM/remote pair.
+type BackupTaskResult struct {
+	Size int64 `json:"size,omitempty"`
+}
+
+// BackupTask is a single node in the tree of work that a backup run
+// performs, e.g. one entry per VM and, nested beneath it, one entry per
+// remote that VM was exported to.
+type BackupTask struct {
+	Id     string           `json:"id"`
+	Status string           `json:"status"`
+	Start  int64            `json:"start"`
+	End    int64            `json:"end,omitempty"`
+	Result BackupTaskResult `json:"result,omitempty"`
+	Tasks  []BackupTask     `json:"tasks,omitempty"`
+}
+
+// BackupLog is the result of a single run of a backup job, as returned by
+// backup.getLogs/log.get.
+type BackupLog struct {
+	Id       string       `json:"id"`
+	Start    int64        `json:"start"`
+	End      int64        `json:"end,omitempty"`
+	Status   string       `json:"status"`
+	Warnings []string     `json:"warnings,omitempty"`
+	Tasks    []BackupTask `json:"tasks,omitempty"`
+}
+
+// RunBackup triggers an on-demand run of the backup job identified by id
+// (optionally restricted to a single schedule via scheduleId), then polls
+// backup.getLogs until that run's log entry reaches a terminal status or
+// timeout elapses.
+func (c *Client) RunBackup(id string, scheduleId string, timeout time.Duration) (*BackupLog, error) {
+	params := map[string]interface{}{
+		"id": id,
+	}
+	if scheduleId != "" {
+		params["schedule"] = scheduleId
+	}
+
+	var runId string
+	err := c.Call("backup.runJob", params, &runId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.waitForBackupRun(id, runId, timeout)
+}
+
+func (c *Client) waitForBackupRun(jobId, runId string, timeout time.Duration) (*BackupLog, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		logEntry, err := c.getBackupLog(jobId, runId)
+		if err != nil {
+			return nil, err
+		}
+
+		if logEntry != nil {
+			switch logEntry.Status {
+			case BackupLogSuccess, BackupLogFailure, BackupLogInterrupted:
+				return logEntry, nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return nil, errors.New(fmt.Sprintf("timed out after %s waiting for run %s of backup job %s to finish", timeout, runId, jobId))
+		}
+
+		time.Sleep(backupLogPollInterval)
+	}
+}
+
+func (c *Client) getBackupLog(jobId, runId string) (*BackupLog, error) {
+	logs, err := c.GetBackupLogs(jobId, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, logEntry := range logs {
+		if logEntry.Id == runId {
+			return &logEntry, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetBackupLogs returns the most recent logs for the backup job
+// identified by jobId, ordered newest first. A limit <= 0 returns every
+// log entry the API has retained.
+func (c *Client) GetBackupLogs(jobId string, limit int) ([]BackupLog, error) {
+	var logs map[string]BackupLog
+	err := c.Call("backup.getLogs", map[string]interface{}{"id": jobId}, &logs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]BackupLog, 0, len(logs))
+	for _, logEntry := range logs {
+		result = append(result, logEntry)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Start > result[j].Start
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+// GetBackupById fetches a single backup job directly by id, rather than
+// going through FindFromGetAllObjects (which lists every backup job and
+// filters client-side). This is used by the importer since the id is
+// already known and a full listing would be wasted work.
+func (c *Client) GetBackupById(id string) (*VmBackup, error) {
+	var backup VmBackup
+	err := c.Call("backup.get", map[string]interface{}{"id": id}, &backup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &backup, nil
+}
+
 func (c *Client) GetBackup(backupReq VmBackup) (*VmBackup, error) {
 	obj, err := c.FindFromGetAllObjects(backupReq)
 
@@ -0,0 +1,25 @@
+package client
+
+import "time"
+
+// XOClient is the subset of *Client that the xoa package depends on. It
+// exists so that resource and data source implementations can depend on
+// an interface (and be exercised against a fake in tests) rather than on
+// the concrete *Client.
+type XOClient interface {
+	CreateBackup(backupReq VmBackup, createTime time.Duration) (*VmBackup, error)
+	UpdateBackup(backupReq VmBackup) (*VmBackup, error)
+	DeleteBackup(id string) error
+	GetBackup(backupReq VmBackup) (*VmBackup, error)
+	GetBackups(backup VmBackup) ([]VmBackup, error)
+	GetBackupById(id string) (*VmBackup, error)
+	RunBackup(id string, scheduleId string, timeout time.Duration) (*BackupLog, error)
+	GetBackupLogs(jobId string, limit int) ([]BackupLog, error)
+
+	CreateRemote(remoteReq Remote) (*Remote, error)
+	UpdateRemote(remoteReq Remote) (*Remote, error)
+	DeleteRemote(id string) error
+	GetRemote(remoteReq Remote) (*Remote, error)
+	GetRemotes(remoteReq Remote) ([]Remote, error)
+	TestRemote(id string) (*RemoteTestResult, error)
+}
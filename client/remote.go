@@ -0,0 +1,148 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// Remote is a storage location (NFS, SMB, local file path or S3 bucket)
+// that backup jobs export to. It is modeled as its own first-class
+// resource, in the same spirit as a health check object in other
+// providers, so that its reachability can be verified independently of
+// any backup job that references it.
+type Remote struct {
+	Id         string            `json:"id,omitempty"`
+	Name       string            `json:"name"`
+	Url        string            `json:"url"`
+	Options    string            `json:"options,omitempty"`
+	Enabled    bool              `json:"enabled"`
+	Proxy      string            `json:"proxy,omitempty"`
+	Encryption *RemoteEncryption `json:"encryptionKey,omitempty"`
+}
+
+// RemoteEncryption configures client-side encryption of the data a
+// remote stores. Algorithm is one of the ciphers Xen Orchestra supports,
+// e.g. "aes-256-gcm".
+type RemoteEncryption struct {
+	Algorithm string `json:"algorithm"`
+	Key       string `json:"key"`
+}
+
+func (r Remote) Compare(obj interface{}) bool {
+	other := obj.(Remote)
+	if r.Id != "" && r.Id == other.Id {
+		return true
+	}
+
+	if r.Name != "" && r.Name == other.Name {
+		return true
+	}
+
+	return false
+}
+
+// RemoteTestResult is the outcome of remote.test, used to verify that a
+// remote is reachable and writable before any backup job relies on it.
+type RemoteTestResult struct {
+	Success   bool    `json:"success"`
+	WriteRate float64 `json:"writeRate,omitempty"`
+	ReadRate  float64 `json:"readRate,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+func (c *Client) CreateRemote(remoteReq Remote) (*Remote, error) {
+	params := map[string]interface{}{
+		"name":    remoteReq.Name,
+		"url":     remoteReq.Url,
+		"options": remoteReq.Options,
+		"proxy":   remoteReq.Proxy,
+	}
+
+	log.Printf("[DEBUG] Remote params for remote.create %#v", params)
+	var remoteId string
+	err := c.Call("remote.create", params, &remoteId)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteReq.Id = remoteId
+	if _, err := c.UpdateRemote(remoteReq); err != nil {
+		return nil, err
+	}
+
+	return c.GetRemote(Remote{Id: remoteId})
+}
+
+func (c *Client) UpdateRemote(remoteReq Remote) (*Remote, error) {
+	params := map[string]interface{}{
+		"id":      remoteReq.Id,
+		"name":    remoteReq.Name,
+		"url":     remoteReq.Url,
+		"options": remoteReq.Options,
+		"enabled": remoteReq.Enabled,
+		"proxy":   remoteReq.Proxy,
+	}
+	if remoteReq.Encryption != nil {
+		params["encryptionKey"] = remoteReq.Encryption
+	}
+
+	var success bool
+	err := c.Call("remote.set", params, &success)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetRemote(Remote{Id: remoteReq.Id})
+}
+
+func (c *Client) DeleteRemote(id string) error {
+	params := map[string]interface{}{
+		"id": id,
+	}
+	var success bool
+	return c.Call("remote.delete", params, &success)
+}
+
+func (c *Client) GetRemote(remoteReq Remote) (*Remote, error) {
+	obj, err := c.FindFromGetAllObjects(remoteReq)
+	if err != nil {
+		return nil, err
+	}
+	remotes := obj.([]Remote)
+
+	if len(remotes) != 1 {
+		return nil, errors.New(fmt.Sprintf("expected to find a single Remote from request %+v, instead found %d", remoteReq, len(remotes)))
+	}
+
+	log.Printf("[DEBUG] Found remote: %+v", remotes[0])
+	return &remotes[0], nil
+}
+
+func (c *Client) GetRemotes(remoteReq Remote) ([]Remote, error) {
+	obj, err := c.FindFromGetAllObjects(remoteReq)
+	if err != nil {
+		return []Remote{}, err
+	}
+	remotes := obj.([]Remote)
+	log.Printf("[DEBUG] Found remotes: %+v", remotes)
+	return remotes, nil
+}
+
+// TestRemote wraps remote.test, which writes and reads back a small file
+// on the remote to verify it is reachable, writable and measure its
+// throughput. It is the basis for the xenorchestra_backup_remote_health
+// data source.
+func (c *Client) TestRemote(id string) (*RemoteTestResult, error) {
+	params := map[string]interface{}{
+		"id": id,
+	}
+
+	var result RemoteTestResult
+	err := c.Call("remote.test", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
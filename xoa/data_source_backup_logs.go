@@ -0,0 +1,101 @@
+package xoa
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vatesfr/terraform-provider-xenorchestra/client"
+	"github.com/vatesfr/terraform-provider-xenorchestra/xoa/internal"
+)
+
+func dataSourceXoaBackupLogs() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to fetch the most recent run logs for a xenorchestra_backup job, optionally filtered by status.",
+		Read:        dataSourceBackupLogsRead,
+		Schema: map[string]*schema.Schema{
+			"backup_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the backup job to fetch logs for.",
+			},
+			"limit": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     10,
+				Description: "The maximum number of logs to return, newest first.",
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return logs with this status. One of `success`, `failure` or `interrupted`.",
+			},
+			"logs": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The logs matching the given search criteria.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"start": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"end": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBackupLogsRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+
+	jobId := d.Get("backup_id").(string)
+	limit := d.Get("limit").(int)
+	status := d.Get("status").(string)
+
+	logs, err := c.GetBackupLogs(jobId, 0)
+	if err != nil {
+		return err
+	}
+
+	filtered := make([]client.BackupLog, 0, len(logs))
+	for _, logEntry := range logs {
+		if status != "" && logEntry.Status != status {
+			continue
+		}
+		filtered = append(filtered, logEntry)
+		if limit > 0 && len(filtered) >= limit {
+			break
+		}
+	}
+
+	if err := d.Set("logs", backupLogsToMapList(filtered)); err != nil {
+		return err
+	}
+
+	d.SetId(internal.Strings([]string{jobId, status}))
+	return nil
+}
+
+func backupLogsToMapList(logs []client.BackupLog) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(logs))
+	for _, logEntry := range logs {
+		result = append(result, map[string]interface{}{
+			"id":     logEntry.Id,
+			"status": logEntry.Status,
+			"start":  logEntry.Start,
+			"end":    logEntry.End,
+		})
+	}
+	return result
+}
@@ -0,0 +1,69 @@
+package xoa
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vatesfr/terraform-provider-xenorchestra/client"
+)
+
+// dataSourceXoaBackupRemoteHealth calls remote.test so that a
+// `depends_on` chain can be gated on a remote actually being reachable
+// and writable before a backup job that references it is created, since
+// a misconfigured remote otherwise fails silently at backup run time.
+func dataSourceXoaBackupRemoteHealth() *schema.Resource {
+	return &schema.Resource{
+		Description: "Verifies that a xenorchestra_backup_remote is reachable and writable by calling remote.test.",
+		Read:        dataSourceBackupRemoteHealthRead,
+		Schema: map[string]*schema.Schema{
+			"remote_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the xenorchestra_backup_remote to test.",
+			},
+			"success": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"write_rate": &schema.Schema{
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The measured write throughput of the remote, in bytes per second.",
+			},
+			"read_rate": &schema.Schema{
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The measured read throughput of the remote, in bytes per second.",
+			},
+			"error": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The error encountered while testing the remote, if any.",
+			},
+		},
+	}
+}
+
+func dataSourceBackupRemoteHealthRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+	remoteId := d.Get("remote_id").(string)
+
+	result, err := c.TestRemote(remoteId)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("success", result.Success); err != nil {
+		return err
+	}
+	if err := d.Set("write_rate", result.WriteRate); err != nil {
+		return err
+	}
+	if err := d.Set("read_rate", result.ReadRate); err != nil {
+		return err
+	}
+	if err := d.Set("error", result.Error); err != nil {
+		return err
+	}
+
+	d.SetId(remoteId)
+	return nil
+}
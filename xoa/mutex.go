@@ -0,0 +1,46 @@
+package xoa
+
+import "sync"
+
+// mutexKV serializes access to a set of keyed resources, such as the
+// backup job a xenorchestra_backup_schedule resource belongs to. It is
+// needed because Terraform runs the Create/Update/Delete of distinct
+// resources sharing a key concurrently by default; without it, two
+// schedules on the same job could race a GetBackup/UpdateBackup
+// read-modify-write cycle and silently drop each other's changes.
+type mutexKV struct {
+	lock  sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+func newMutexKV() *mutexKV {
+	return &mutexKV{
+		store: make(map[string]*sync.Mutex),
+	}
+}
+
+func (m *mutexKV) Lock(key string) {
+	m.get(key).Lock()
+}
+
+func (m *mutexKV) Unlock(key string) {
+	m.get(key).Unlock()
+}
+
+func (m *mutexKV) get(key string) *sync.Mutex {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+	return mutex
+}
+
+// backupScheduleMutex serializes read-modify-write cycles against a
+// single backup job's Schedules, keyed by job id, so that concurrently
+// applied xenorchestra_backup_schedule resources belonging to the same
+// xenorchestra_backup can't overwrite one another.
+var backupScheduleMutex = newMutexKV()
@@ -0,0 +1,29 @@
+package xoa
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vatesfr/terraform-provider-xenorchestra/client"
+)
+
+// Provider returns the xenorchestra Terraform provider, wiring up every
+// backup-related resource and data source defined in this package.
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"xenorchestra_backup":          resourceBackup(),
+			"xenorchestra_backup_schedule": resourceBackupSchedule(),
+			"xenorchestra_backup_remote":   resourceBackupRemote(),
+			"xenorchestra_backup_run":      resourceBackupRun(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"xenorchestra_backups":              dataSourceXoaBackups(),
+			"xenorchestra_backup_logs":          dataSourceXoaBackupLogs(),
+			"xenorchestra_backup_remote_health": dataSourceXoaBackupRemoteHealth(),
+		},
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	return client.NewClient(client.GetConfigFromEnv())
+}
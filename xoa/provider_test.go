@@ -0,0 +1,42 @@
+package xoa
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var testAccProvider *schema.Provider
+var testAccProviders map[string]*schema.Provider
+var testAccProviderFactories map[string]func() (*schema.Provider, error)
+
+func init() {
+	testAccProvider = Provider()
+	testAccProviders = map[string]*schema.Provider{
+		"xenorchestra": testAccProvider,
+	}
+	testAccProviderFactories = map[string]func() (*schema.Provider, error){
+		"xenorchestra": func() (*schema.Provider, error) {
+			return Provider(), nil
+		},
+	}
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+// testAccPreCheck verifies that the environment variables the acceptance
+// tests need to reach a real Xen Orchestra instance are set, and fails
+// the test up front (via t.Fatalf, per the SDK's convention) rather than
+// obscurely partway through a test run.
+func testAccPreCheck(t *testing.T) {
+	for _, envVar := range []string{"XOA_URL", "XOA_USER", "XOA_PASSWORD", "XOA_VM_ID"} {
+		if os.Getenv(envVar) == "" {
+			t.Fatalf("%s must be set for acceptance tests", envVar)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package xoa
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vatesfr/terraform-provider-xenorchestra/client"
+)
+
+// createBackupTimeout is the createTime that resourceBackupCreate passes
+// to CreateBackup, bounding how long it waits after backup.create.
+const createBackupTimeout = 5 * time.Minute
+
+func resourceBackup() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Creates a Xenorchestra backup job that exports or replicates the given VMs to the given remotes on the schedules defined in the `schedule` blocks.",
+		Create:        resourceBackupCreate,
+		Read:          resourceBackupRead,
+		Update:        resourceBackupUpdate,
+		Delete:        resourceBackupDelete,
+		CustomizeDiff: resourceBackupValidateRemotes,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBackupImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the backup job.",
+			},
+			"mode": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The backup mode. Must be one of `full` or `delta`.",
+			},
+			"type": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The type of backup job. One of `VM`, `Mirror` or `Metadata`.",
+			},
+			"vms": &schema.Schema{
+				Type:        schema.TypeMap,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A map of VM ids that should be included in this backup job.",
+			},
+			"remotes": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A map of remote ids that this backup job exports to. Each id must refer to an existing `xenorchestra_backup_remote`.",
+			},
+			"settings": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Job-level settings such as `reportWhen`, `concurrency` and `timeout` that apply regardless of schedule.",
+			},
+			"schedule": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Description: "A schedule that this backup job runs on. Multiple schedules (e.g. a nightly delta and a weekly full) can be declared.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The id assigned to this schedule by Xen Orchestra.",
+						},
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "A name unique among this job's schedules. Schedules are matched to server state by name rather than by their position in the list, so inserting or reordering schedule blocks can't cause one schedule's settings to overwrite another.",
+						},
+						"cron": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The cron expression that this schedule runs on.",
+						},
+						"timezone": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"retention": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"export_retention": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"copy_retention": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"snapshot_retention": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"full_interval": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceBackupCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+	backupReq := backupFromResourceData(d, nil)
+
+	backup, err := c.CreateBackup(backupReq, createBackupTimeout)
+	if err != nil {
+		return err
+	}
+
+	return backupToResourceData(backup, d)
+}
+
+func resourceBackupRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+	backup, err := c.GetBackup(client.VmBackup{Id: d.Id()})
+	if _, ok := err.(client.NotFound); ok {
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return backupToResourceData(backup, d)
+}
+
+func resourceBackupUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+
+	// Fetch the current, authoritative schedule ids and resolve this
+	// config's schedule blocks against them by name rather than by their
+	// position in the `schedule` list. TypeList elements are otherwise
+	// matched positionally, so inserting or removing a block anywhere but
+	// the end would silently attribute the wrong id - and therefore the
+	// wrong server-side schedule - to every later block.
+	current, err := c.GetBackup(client.VmBackup{Id: d.Id()})
+	if err != nil {
+		return err
+	}
+
+	backupReq := backupFromResourceData(d, scheduleIdsByName(current.Schedules))
+	backupReq.Id = d.Id()
+
+	backup, err := c.UpdateBackup(backupReq)
+	if err != nil {
+		return err
+	}
+
+	return backupToResourceData(backup, d)
+}
+
+func resourceBackupDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+	err := c.DeleteBackup(d.Id())
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// backupFromResourceData builds the VmBackup to send to the API from the
+// resource's config/state. existingScheduleIds maps a schedule's name to
+// its current server-side id (nil on create, when no schedule has one
+// yet) and is used to resolve each schedule block's id instead of
+// trusting its `id` attribute, which Terraform can misattribute when
+// schedule blocks are reordered.
+func backupFromResourceData(d *schema.ResourceData, existingScheduleIds map[string]string) client.VmBackup {
+	return client.VmBackup{
+		Name:      d.Get("name").(string),
+		Mode:      d.Get("mode").(string),
+		Type:      d.Get("type").(string),
+		Vms:       toStringMap(d.Get("vms").(map[string]interface{})),
+		Remotes:   toStringMap(d.Get("remotes").(map[string]interface{})),
+		Settings:  toStringMap(d.Get("settings").(map[string]interface{})),
+		Schedules: schedulesFromResourceData(d.Get("schedule").([]interface{}), existingScheduleIds),
+	}
+}
+
+// scheduleIdsByName indexes a backup's current schedules by name so a new
+// config's schedule blocks can be matched to their existing server-side
+// id by name rather than by list position.
+func scheduleIdsByName(schedules []client.BackupSchedule) map[string]string {
+	ids := make(map[string]string, len(schedules))
+	for _, schedule := range schedules {
+		ids[schedule.Name] = schedule.Id
+	}
+	return ids
+}
+
+func schedulesFromResourceData(schedules []interface{}, existingScheduleIds map[string]string) []client.BackupSchedule {
+	result := make([]client.BackupSchedule, 0, len(schedules))
+	for _, s := range schedules {
+		schedule := s.(map[string]interface{})
+		name := schedule["name"].(string)
+		result = append(result, client.BackupSchedule{
+			Id:        existingScheduleIds[name],
+			Name:      name,
+			Cron:      schedule["cron"].(string),
+			Timezone:  schedule["timezone"].(string),
+			Enabled:   schedule["enabled"].(bool),
+			Retention: retentionFromResourceData(schedule["retention"].([]interface{})),
+		})
+	}
+	return result
+}
+
+func retentionFromResourceData(retention []interface{}) client.Retention {
+	if len(retention) == 0 {
+		return client.Retention{}
+	}
+	r := retention[0].(map[string]interface{})
+	return client.Retention{
+		ExportRetention:   r["export_retention"].(int),
+		CopyRetention:     r["copy_retention"].(int),
+		SnapshotRetention: r["snapshot_retention"].(int),
+		FullInterval:      r["full_interval"].(int),
+	}
+}
+
+func backupToResourceData(backup *client.VmBackup, d *schema.ResourceData) error {
+	d.SetId(backup.Id)
+	if err := d.Set("name", backup.Name); err != nil {
+		return err
+	}
+	if err := d.Set("mode", backup.Mode); err != nil {
+		return err
+	}
+	if err := d.Set("type", backup.Type); err != nil {
+		return err
+	}
+	if err := d.Set("vms", backup.Vms); err != nil {
+		return err
+	}
+	if err := d.Set("remotes", backup.Remotes); err != nil {
+		return err
+	}
+	if err := d.Set("settings", backup.Settings); err != nil {
+		return err
+	}
+	return d.Set("schedule", schedulesToMapList(backup.Schedules))
+}
+
+func schedulesToMapList(schedules []client.BackupSchedule) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(schedules))
+	for _, schedule := range schedules {
+		result = append(result, map[string]interface{}{
+			"id":       schedule.Id,
+			"name":     schedule.Name,
+			"cron":     schedule.Cron,
+			"timezone": schedule.Timezone,
+			"enabled":  schedule.Enabled,
+			"retention": []map[string]interface{}{
+				{
+					"export_retention":   schedule.Retention.ExportRetention,
+					"copy_retention":     schedule.Retention.CopyRetention,
+					"snapshot_retention": schedule.Retention.SnapshotRetention,
+					"full_interval":      schedule.Retention.FullInterval,
+				},
+			},
+		})
+	}
+	return result
+}
+
+func toStringMap(m map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v.(string)
+	}
+	return result
+}
+
+// parseBackupImportId splits a `terraform import` id of the form
+// `jobId[/scheduleId]` into its parts. scheduleId is empty when the
+// caller only supplied a job id, which is the form xenorchestra_backup
+// import accepts; xenorchestra_backup_schedule requires both parts.
+func parseBackupImportId(id string) (jobId string, scheduleId string) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return parts[0], ""
+}
+
+// resourceBackupImport allows `terraform import xenorchestra_backup.foo
+// <jobId>` to populate every nested block - vms, remotes, settings and
+// schedule - with server-truth values via GetBackupById.
+func resourceBackupImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(client.XOClient)
+	jobId, _ := parseBackupImportId(d.Id())
+
+	backup, err := c.GetBackupById(jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := backupToResourceData(backup, d); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceBackupValidateRemotes checks at plan time that every remote id
+// referenced in `remotes` exists, so that a typo'd remote id surfaces as
+// a plan error rather than a backup job that silently fails to export
+// anywhere.
+func resourceBackupValidateRemotes(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// remotes can reference a xenorchestra_backup_remote created in the
+	// same apply, in which case its id isn't known yet at plan time.
+	// Skip validation rather than erroring plan or checking a stale/empty
+	// id; the create/update call itself will still fail loudly if the
+	// remote turns out not to exist.
+	if !d.NewValueKnown("remotes") {
+		return nil
+	}
+
+	c := m.(client.XOClient)
+	remotes := d.Get("remotes").(map[string]interface{})
+
+	for _, remoteId := range remotes {
+		id := remoteId.(string)
+		if _, err := c.GetRemote(client.Remote{Id: id}); err != nil {
+			return fmt.Errorf("remote %q referenced in remotes does not exist: %w", id, err)
+		}
+	}
+
+	return nil
+}
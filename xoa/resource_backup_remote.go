@@ -0,0 +1,160 @@
+package xoa
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vatesfr/terraform-provider-xenorchestra/client"
+)
+
+func resourceBackupRemote() *schema.Resource {
+	return &schema.Resource{
+		Description: "Creates a Xenorchestra remote, a storage location (NFS, SMB, local file path or S3) that xenorchestra_backup jobs can export to. Use the xenorchestra_backup_remote_health data source to verify it is reachable before referencing it from a backup job.",
+		Create:      resourceBackupRemoteCreate,
+		Read:        resourceBackupRemoteRead,
+		Update:      resourceBackupRemoteUpdate,
+		Delete:      resourceBackupRemoteDelete,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"url": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The remote's connection string, e.g. `nfs://host/path`, `smb://host/share`, `file:///path` or `s3://bucket/path`.",
+			},
+			"options": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"proxy": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The id of the XO proxy to use when connecting to this remote.",
+			},
+			"encryption": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"algorithm": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The cipher used to encrypt data written to this remote, e.g. `aes-256-gcm`.",
+						},
+						"key": &schema.Schema{
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceBackupRemoteCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+	remote, err := c.CreateRemote(remoteFromResourceData(d))
+	if err != nil {
+		return err
+	}
+
+	return remoteToResourceData(remote, d)
+}
+
+func resourceBackupRemoteRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+	remote, err := c.GetRemote(client.Remote{Id: d.Id()})
+	if _, ok := err.(client.NotFound); ok {
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return remoteToResourceData(remote, d)
+}
+
+func resourceBackupRemoteUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+	remoteReq := remoteFromResourceData(d)
+	remoteReq.Id = d.Id()
+
+	remote, err := c.UpdateRemote(remoteReq)
+	if err != nil {
+		return err
+	}
+
+	return remoteToResourceData(remote, d)
+}
+
+func resourceBackupRemoteDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+	if err := c.DeleteRemote(d.Id()); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func remoteFromResourceData(d *schema.ResourceData) client.Remote {
+	return client.Remote{
+		Name:       d.Get("name").(string),
+		Url:        d.Get("url").(string),
+		Options:    d.Get("options").(string),
+		Enabled:    d.Get("enabled").(bool),
+		Proxy:      d.Get("proxy").(string),
+		Encryption: remoteEncryptionFromResourceData(d.Get("encryption").([]interface{})),
+	}
+}
+
+func remoteEncryptionFromResourceData(encryption []interface{}) *client.RemoteEncryption {
+	if len(encryption) == 0 {
+		return nil
+	}
+	e := encryption[0].(map[string]interface{})
+	return &client.RemoteEncryption{
+		Algorithm: e["algorithm"].(string),
+		Key:       e["key"].(string),
+	}
+}
+
+func remoteEncryptionToMapList(encryption *client.RemoteEncryption) []map[string]interface{} {
+	if encryption == nil {
+		return nil
+	}
+	return []map[string]interface{}{
+		{
+			"algorithm": encryption.Algorithm,
+			"key":       encryption.Key,
+		},
+	}
+}
+
+func remoteToResourceData(remote *client.Remote, d *schema.ResourceData) error {
+	d.SetId(remote.Id)
+	if err := d.Set("name", remote.Name); err != nil {
+		return err
+	}
+	if err := d.Set("url", remote.Url); err != nil {
+		return err
+	}
+	if err := d.Set("options", remote.Options); err != nil {
+		return err
+	}
+	if err := d.Set("enabled", remote.Enabled); err != nil {
+		return err
+	}
+	if err := d.Set("proxy", remote.Proxy); err != nil {
+		return err
+	}
+	return d.Set("encryption", remoteEncryptionToMapList(remote.Encryption))
+}
@@ -0,0 +1,133 @@
+package xoa
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vatesfr/terraform-provider-xenorchestra/client"
+)
+
+// defaultBackupRunTimeout bounds how long resourceBackupRunCreate waits
+// for a triggered backup run to reach a terminal status.
+const defaultBackupRunTimeout = 2 * time.Hour
+
+// resourceBackupRun triggers an on-demand run of a xenorchestra_backup
+// job whenever the `triggers` map changes, in the same spirit as the
+// null_resource `triggers` attribute. This lets a run be chained after
+// other resources (e.g. `depends_on` a freshly created VM) and fails the
+// apply if the backup run itself fails.
+func resourceBackupRun() *schema.Resource {
+	return &schema.Resource{
+		Description: "Runs a xenorchestra_backup job on demand whenever `triggers` changes, and fails the apply if the run does not succeed.",
+		Create:      resourceBackupRunCreate,
+		Read:        resourceBackupRunRead,
+		Delete:      resourceBackupRunDelete,
+		Schema: map[string]*schema.Schema{
+			"backup_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the xenorchestra_backup job to run.",
+			},
+			"schedule_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Restrict the run to a single schedule on the backup job. Defaults to running every schedule.",
+			},
+			"timeout_seconds": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     int(defaultBackupRunTimeout / time.Second),
+				Description: "How long to wait, in seconds, for the run to reach a terminal status before giving up.",
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "An arbitrary map of values that, when changed, cause the backup job to be run again.",
+			},
+			"log_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"start": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"end": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceBackupRunCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+
+	jobId := d.Get("backup_id").(string)
+	scheduleId := d.Get("schedule_id").(string)
+	timeout := time.Duration(d.Get("timeout_seconds").(int)) * time.Second
+
+	logEntry, err := c.RunBackup(jobId, scheduleId, timeout)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(logEntry.Id)
+	if err := backupRunLogToResourceData(logEntry, d); err != nil {
+		return err
+	}
+
+	if logEntry.Status != client.BackupLogSuccess {
+		return fmt.Errorf("backup job %s run %s finished with status %q instead of %q", jobId, logEntry.Id, logEntry.Status, client.BackupLogSuccess)
+	}
+
+	return nil
+}
+
+func resourceBackupRunRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+
+	logs, err := c.GetBackupLogs(d.Get("backup_id").(string), 0)
+	if err != nil {
+		return err
+	}
+
+	for _, logEntry := range logs {
+		if logEntry.Id == d.Id() {
+			return backupRunLogToResourceData(&logEntry, d)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceBackupRunDelete(d *schema.ResourceData, m interface{}) error {
+	// There is nothing to tear down on the server side; the run already
+	// happened. Removing this resource only forgets about it in state.
+	d.SetId("")
+	return nil
+}
+
+func backupRunLogToResourceData(logEntry *client.BackupLog, d *schema.ResourceData) error {
+	if err := d.Set("log_id", logEntry.Id); err != nil {
+		return err
+	}
+	if err := d.Set("status", logEntry.Status); err != nil {
+		return err
+	}
+	if err := d.Set("start", logEntry.Start); err != nil {
+		return err
+	}
+	return d.Set("end", logEntry.End)
+}
@@ -0,0 +1,303 @@
+package xoa
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vatesfr/terraform-provider-xenorchestra/client"
+)
+
+// resourceBackupSchedule manages a single schedule nested inside a
+// xenorchestra_backup job. It is useful when schedules are owned by a
+// different team or module than the job itself, or when schedules need
+// to be added/removed without forcing a diff on the rest of the job.
+func resourceBackupSchedule() *schema.Resource {
+	return &schema.Resource{
+		Description: "Creates a single cron schedule on an existing `xenorchestra_backup` job. Use this when a schedule should be managed independently of the backup job it belongs to.",
+		Create:      resourceBackupScheduleCreate,
+		Read:        resourceBackupScheduleRead,
+		Update:      resourceBackupScheduleUpdate,
+		Delete:      resourceBackupScheduleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBackupScheduleImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"backup_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the xenorchestra_backup job this schedule belongs to.",
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"cron": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The cron expression that this schedule runs on.",
+			},
+			"timezone": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"export_retention": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"copy_retention": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"snapshot_retention": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"full_interval": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func backupScheduleId(jobId, scheduleId string) string {
+	return fmt.Sprintf("%s/%s", jobId, scheduleId)
+}
+
+func resourceBackupScheduleCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+	jobId := d.Get("backup_id").(string)
+
+	// Multiple xenorchestra_backup_schedule resources can share a
+	// backup_id, and each Create does a read-modify-write of the whole
+	// job's Schedules. Serialize per job id so two schedules created in
+	// the same apply can't both read the same starting state and have
+	// one clobber the other's UpdateBackup.
+	backupScheduleMutex.Lock(jobId)
+	defer backupScheduleMutex.Unlock(jobId)
+
+	backup, err := c.GetBackup(client.VmBackup{Id: jobId})
+	if err != nil {
+		return err
+	}
+	existingIds := scheduleIdSet(backup.Schedules)
+
+	backup.Schedules = append(backup.Schedules, scheduleFromResourceData(d))
+	updated, err := c.UpdateBackup(*backup)
+	if err != nil {
+		return err
+	}
+
+	schedule, err := newScheduleSince(updated.Schedules, existingIds)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(backupScheduleId(jobId, schedule.Id))
+	return scheduleToResourceData(schedule, d)
+}
+
+func resourceBackupScheduleRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+	jobId, scheduleId, err := parseBackupScheduleId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	backup, err := c.GetBackup(client.VmBackup{Id: jobId})
+	if _, ok := err.(client.NotFound); ok {
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, schedule := range backup.Schedules {
+		if schedule.Id == scheduleId {
+			return scheduleToResourceData(&schedule, d)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceBackupScheduleUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+	jobId, scheduleId, err := parseBackupScheduleId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	backupScheduleMutex.Lock(jobId)
+	defer backupScheduleMutex.Unlock(jobId)
+
+	backup, err := c.GetBackup(client.VmBackup{Id: jobId})
+	if err != nil {
+		return err
+	}
+
+	updatedSchedule := scheduleFromResourceData(d)
+	updatedSchedule.Id = scheduleId
+	for i, schedule := range backup.Schedules {
+		if schedule.Id == scheduleId {
+			backup.Schedules[i] = updatedSchedule
+		}
+	}
+
+	updated, err := c.UpdateBackup(*backup)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range updated.Schedules {
+		if schedule.Id == scheduleId {
+			return scheduleToResourceData(&schedule, d)
+		}
+	}
+
+	return fmt.Errorf("schedule %s was not found on backup %s after update", scheduleId, jobId)
+}
+
+func resourceBackupScheduleDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(client.XOClient)
+	jobId, scheduleId, err := parseBackupScheduleId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	backupScheduleMutex.Lock(jobId)
+	defer backupScheduleMutex.Unlock(jobId)
+
+	backup, err := c.GetBackup(client.VmBackup{Id: jobId})
+	if _, ok := err.(client.NotFound); ok {
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	remaining := make([]client.BackupSchedule, 0, len(backup.Schedules))
+	for _, schedule := range backup.Schedules {
+		if schedule.Id != scheduleId {
+			remaining = append(remaining, schedule)
+		}
+	}
+	backup.Schedules = remaining
+
+	_, err = c.UpdateBackup(*backup)
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceBackupScheduleImport allows `terraform import
+// xenorchestra_backup_schedule.nightly <jobId>/<scheduleId>` to populate
+// the resource with server-truth values via GetBackupById.
+func resourceBackupScheduleImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(client.XOClient)
+	jobId, scheduleId := parseBackupImportId(d.Id())
+	if scheduleId == "" {
+		return nil, fmt.Errorf("expected import id to be of the form <jobId>/<scheduleId>, got: %s", d.Id())
+	}
+
+	backup, err := c.GetBackupById(jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, schedule := range backup.Schedules {
+		if schedule.Id == scheduleId {
+			if err := d.Set("backup_id", jobId); err != nil {
+				return nil, err
+			}
+			if err := scheduleToResourceData(&schedule, d); err != nil {
+				return nil, err
+			}
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("schedule %s was not found on backup %s", scheduleId, jobId)
+}
+
+func parseBackupScheduleId(id string) (string, string, error) {
+	jobId, scheduleId := parseBackupImportId(id)
+	if scheduleId == "" {
+		return "", "", fmt.Errorf("expected backup schedule id to be of the form <jobId>/<scheduleId>, got: %s", id)
+	}
+	return jobId, scheduleId, nil
+}
+
+func scheduleFromResourceData(d *schema.ResourceData) client.BackupSchedule {
+	return client.BackupSchedule{
+		Name:     d.Get("name").(string),
+		Cron:     d.Get("cron").(string),
+		Timezone: d.Get("timezone").(string),
+		Enabled:  d.Get("enabled").(bool),
+		Retention: client.Retention{
+			ExportRetention:   d.Get("export_retention").(int),
+			CopyRetention:     d.Get("copy_retention").(int),
+			SnapshotRetention: d.Get("snapshot_retention").(int),
+			FullInterval:      d.Get("full_interval").(int),
+		},
+	}
+}
+
+func scheduleToResourceData(schedule *client.BackupSchedule, d *schema.ResourceData) error {
+	if err := d.Set("name", schedule.Name); err != nil {
+		return err
+	}
+	if err := d.Set("cron", schedule.Cron); err != nil {
+		return err
+	}
+	if err := d.Set("timezone", schedule.Timezone); err != nil {
+		return err
+	}
+	if err := d.Set("enabled", schedule.Enabled); err != nil {
+		return err
+	}
+	if err := d.Set("export_retention", schedule.Retention.ExportRetention); err != nil {
+		return err
+	}
+	if err := d.Set("copy_retention", schedule.Retention.CopyRetention); err != nil {
+		return err
+	}
+	if err := d.Set("snapshot_retention", schedule.Retention.SnapshotRetention); err != nil {
+		return err
+	}
+	return d.Set("full_interval", schedule.Retention.FullInterval)
+}
+
+func scheduleIdSet(schedules []client.BackupSchedule) map[string]bool {
+	ids := make(map[string]bool, len(schedules))
+	for _, schedule := range schedules {
+		ids[schedule.Id] = true
+	}
+	return ids
+}
+
+// newScheduleSince returns the schedule in `schedules` whose id was not
+// present in `existingIds`, i.e. the one the API just assigned to the
+// schedule we appended. Diffing id sets (rather than matching on a field
+// like Cron, which two schedules can legitimately share) keeps this
+// correct even when the new schedule's cron expression collides with an
+// existing one.
+func newScheduleSince(schedules []client.BackupSchedule, existingIds map[string]bool) (*client.BackupSchedule, error) {
+	for i := range schedules {
+		if !existingIds[schedules[i].Id] {
+			return &schedules[i], nil
+		}
+	}
+	return nil, fmt.Errorf("could not find newly created schedule among %d schedules on the updated backup", len(schedules))
+}
@@ -0,0 +1,86 @@
+package xoa
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vatesfr/terraform-provider-xenorchestra/client"
+)
+
+// TestAccXenorchestraBackupSchedule_importBasic creates a backup job with
+// a standalone schedule, imports the schedule via its `<jobId>/<scheduleId>`
+// composite id, and asserts via ImportStateVerify that the imported
+// attributes match the state recorded when the schedule was created.
+func TestAccXenorchestraBackupSchedule_importBasic(t *testing.T) {
+	resourceName := "xenorchestra_backup_schedule.nightly"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckXenorchestraBackupScheduleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBackupScheduleConfig(),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckXenorchestraBackupScheduleDestroy(s *terraform.State) error {
+	c := testAccProvider.Meta().(client.XOClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "xenorchestra_backup_schedule" {
+			continue
+		}
+
+		jobId, scheduleId, err := parseBackupScheduleId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		backup, err := c.GetBackup(client.VmBackup{Id: jobId})
+		if _, ok := err.(client.NotFound); ok {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		for _, schedule := range backup.Schedules {
+			if schedule.Id == scheduleId {
+				return fmt.Errorf("expected schedule %s to have been removed from backup %s", scheduleId, jobId)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccBackupScheduleConfig() string {
+	return fmt.Sprintf(`
+resource "xenorchestra_backup" "backup" {
+  name = "terraform-provider-xenorchestra acceptance test backup"
+  mode = "delta"
+
+  vms = {
+    "0" = "%s"
+  }
+}
+
+resource "xenorchestra_backup_schedule" "nightly" {
+  backup_id = xenorchestra_backup.backup.id
+  cron      = "0 2 * * *"
+  enabled   = true
+
+  export_retention = 5
+}
+`, os.Getenv("XOA_VM_ID"))
+}
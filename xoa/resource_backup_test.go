@@ -0,0 +1,98 @@
+package xoa
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vatesfr/terraform-provider-xenorchestra/client"
+)
+
+// TestAccXenorchestraBackup_importBasic creates a backup job with a
+// single schedule via the provider, imports it by job id, and asserts
+// via ImportStateVerify that the imported attributes match the state
+// recorded when the job was created - the standard Terraform contract
+// for an importable resource.
+func TestAccXenorchestraBackup_importBasic(t *testing.T) {
+	resourceName := "xenorchestra_backup.backup"
+	var backup client.VmBackup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckXenorchestraBackupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBackupConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckXenorchestraBackupExists(resourceName, &backup),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckXenorchestraBackupExists(n string, backup *client.VmBackup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("could not find resource: %s", n)
+		}
+
+		c := testAccProvider.Meta().(client.XOClient)
+		found, err := c.GetBackup(client.VmBackup{Id: rs.Primary.ID})
+		if err != nil {
+			return err
+		}
+
+		*backup = *found
+		return nil
+	}
+}
+
+func testAccCheckXenorchestraBackupDestroy(s *terraform.State) error {
+	c := testAccProvider.Meta().(client.XOClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "xenorchestra_backup" {
+			continue
+		}
+
+		_, err := c.GetBackup(client.VmBackup{Id: rs.Primary.ID})
+		if _, ok := err.(client.NotFound); !ok {
+			return fmt.Errorf("expected backup job %s to have been destroyed", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccBackupConfig() string {
+	return fmt.Sprintf(`
+resource "xenorchestra_backup" "backup" {
+  name = "terraform-provider-xenorchestra acceptance test backup"
+  mode = "delta"
+
+  vms = {
+    "0" = "%s"
+  }
+
+  schedule {
+    name     = "nightly"
+    cron     = "0 4 * * *"
+    enabled  = true
+
+    retention {
+      export_retention = 3
+    }
+  }
+}
+`, os.Getenv("XOA_VM_ID"))
+}